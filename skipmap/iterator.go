@@ -0,0 +1,175 @@
+package skipmap
+
+// Iterator walks a SkipMap's bottom level, which is doubly linked via each
+// Node's back pointer, so it can move in either direction. There is no
+// global lock to hold for the iterator's lifetime here (Put/Get/Remove are
+// already lock-free): Go's garbage collector keeps a node reachable for as
+// long as the iterator references it, so a long-lived scan never blocks a
+// concurrent writer and never dangles even after the node it is parked on
+// is concurrently removed. Close releases the iterator's reference so the
+// node can be collected once nothing else needs it.
+type Iterator[K any, V any] struct {
+	s      *SkipMap[K, V]
+	node   *Node[K, V] // s.header means "before the first entry"
+	valid  bool
+	closed bool
+}
+
+// Iterator returns an Iterator positioned before the first entry; call
+// Next to advance to it.
+func (s *SkipMap[K, V]) Iterator() *Iterator[K, V] {
+	return &Iterator[K, V]{s: s, node: s.header}
+}
+
+// SeekGE returns an Iterator positioned at the first entry with a key
+// greater than or equal to key, or an invalid Iterator if there is none.
+func (s *SkipMap[K, V]) SeekGE(key K) *Iterator[K, V] {
+	it := &Iterator[K, V]{s: s, node: s.header}
+
+	pred := s.header
+	var curr *Node[K, V]
+	for i := s.maxLevel - 1; i >= 0; i-- {
+		curr = pred.forward[i].Load()
+		for curr != nil && s.comparator(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.forward[i].Load()
+		}
+	}
+	for curr != nil && (!curr.fullyLinked.Load() || curr.marked.Load()) {
+		curr = curr.forward[0].Load()
+	}
+
+	if curr != nil {
+		it.node = curr
+		it.valid = true
+	}
+	return it
+}
+
+// SeekLE returns an Iterator positioned at the last entry with a key less
+// than or equal to key, or an invalid Iterator if there is none.
+func (s *SkipMap[K, V]) SeekLE(key K) *Iterator[K, V] {
+	it := &Iterator[K, V]{s: s, node: s.header}
+
+	preds := make([]*Node[K, V], s.maxLevel)
+	succs := make([]*Node[K, V], s.maxLevel)
+	lFound := s.find(key, preds, succs)
+
+	var target *Node[K, V]
+	if lFound != -1 && succs[lFound].fullyLinked.Load() && !succs[lFound].marked.Load() {
+		target = succs[lFound]
+	} else {
+		target = preds[0]
+		for target != s.header && (target.marked.Load() || !target.fullyLinked.Load()) {
+			target = target.back.Load()
+		}
+		if target == s.header {
+			target = nil
+		}
+	}
+
+	if target != nil {
+		it.node = target
+		it.valid = true
+	}
+	return it
+}
+
+// Next advances the iterator to the next live entry and reports whether
+// one was found. Nodes marked for deletion (or not yet fully linked) are
+// skipped over.
+func (it *Iterator[K, V]) Next() bool {
+	if it.closed {
+		return false
+	}
+
+	curr := it.node.forward[0].Load()
+	for curr != nil && (!curr.fullyLinked.Load() || curr.marked.Load()) {
+		curr = curr.forward[0].Load()
+	}
+
+	if curr == nil {
+		it.valid = false
+		return false
+	}
+	it.node = curr
+	it.valid = true
+	return true
+}
+
+// Prev moves the iterator to the previous live entry and reports whether
+// one was found. Once Next or Prev has returned false at one end of the
+// map, continue in the same direction only after a fresh Seek.
+func (it *Iterator[K, V]) Prev() bool {
+	if it.closed || it.node == it.s.header {
+		it.valid = false
+		return false
+	}
+
+	curr := it.node.back.Load()
+	for curr != it.s.header && (curr.marked.Load() || !curr.fullyLinked.Load()) {
+		curr = curr.back.Load()
+	}
+
+	if curr == it.s.header {
+		it.node = it.s.header
+		it.valid = false
+		return false
+	}
+	it.node = curr
+	it.valid = true
+	return true
+}
+
+// Key returns the current entry's key. It panics if Valid is false.
+func (it *Iterator[K, V]) Key() K {
+	if !it.Valid() {
+		panic("skipmap: Key called on an invalid Iterator")
+	}
+	return it.node.key
+}
+
+// Value returns the current entry's value. It panics if Valid is false.
+func (it *Iterator[K, V]) Value() V {
+	if !it.Valid() {
+		panic("skipmap: Value called on an invalid Iterator")
+	}
+	return *it.node.value.Load()
+}
+
+// Valid reports whether the iterator is positioned at a live entry.
+func (it *Iterator[K, V]) Valid() bool {
+	return it.valid && !it.closed
+}
+
+// Close releases the iterator's reference to the map. A closed iterator is
+// always invalid and Next/Prev always return false.
+func (it *Iterator[K, V]) Close() {
+	it.closed = true
+	it.valid = false
+	it.node = nil
+	it.s = nil
+}
+
+// Keys returns every key in the map in ascending order, built on top of an
+// Iterator rather than the older per-call Range slice allocation.
+func (s *SkipMap[K, V]) Keys() []K {
+	keys := make([]K, 0, s.Len())
+	it := s.Iterator()
+	defer it.Close()
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// Entries returns every key-value pair in the map in ascending key order.
+func (s *SkipMap[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, s.Len())
+	it := s.Iterator()
+	defer it.Close()
+	for it.Next() {
+		entries = append(entries, Entry[K, V]{Key: it.Key(), Value: it.Value()})
+	}
+	return entries
+}
@@ -0,0 +1,173 @@
+package skipmap
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestIterator_ForwardScan(t *testing.T) {
+	s := New[int, string]()
+	for i := 0; i < 10; i += 2 {
+		s.Put(i, "v")
+	}
+
+	it := s.Iterator()
+	defer it.Close()
+
+	var keys []int
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+
+	expected := []int{0, 2, 4, 6, 8}
+	if len(keys) != len(expected) {
+		t.Fatalf("got %d keys, want %d", len(keys), len(expected))
+	}
+	for i, k := range keys {
+		if k != expected[i] {
+			t.Errorf("keys[%d] = %d, want %d", i, k, expected[i])
+		}
+	}
+}
+
+func TestIterator_Prev(t *testing.T) {
+	s := New[int, string]()
+	for i := 0; i < 5; i++ {
+		s.Put(i, "v")
+	}
+
+	it := s.SeekGE(4)
+	defer it.Close()
+
+	if !it.Valid() || it.Key() != 4 {
+		t.Fatalf("SeekGE(4) = (%v, valid=%v), want (4, true)", it.Key(), it.Valid())
+	}
+
+	var keys []int
+	keys = append(keys, it.Key())
+	for it.Prev() {
+		keys = append(keys, it.Key())
+	}
+
+	expected := []int{4, 3, 2, 1, 0}
+	if len(keys) != len(expected) {
+		t.Fatalf("got %d keys walking backward, want %d", len(keys), len(expected))
+	}
+	for i, k := range keys {
+		if k != expected[i] {
+			t.Errorf("keys[%d] = %d, want %d", i, k, expected[i])
+		}
+	}
+	if it.Prev() {
+		t.Error("expected Prev to fail once walked past the first entry")
+	}
+}
+
+func TestIterator_SeekGEAndSeekLE(t *testing.T) {
+	s := New[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		s.Put(k, "v")
+	}
+
+	if it := s.SeekGE(15); !it.Valid() || it.Key() != 20 {
+		t.Errorf("SeekGE(15) = (%v, %v), want (20, true)", it.Key(), it.Valid())
+	}
+	if it := s.SeekGE(20); !it.Valid() || it.Key() != 20 {
+		t.Errorf("SeekGE(20) = (%v, %v), want (20, true)", it.Key(), it.Valid())
+	}
+	if it := s.SeekGE(31); it.Valid() {
+		t.Errorf("SeekGE(31) = valid, want invalid")
+	}
+
+	if it := s.SeekLE(25); !it.Valid() || it.Key() != 20 {
+		t.Errorf("SeekLE(25) = (%v, %v), want (20, true)", it.Key(), it.Valid())
+	}
+	if it := s.SeekLE(20); !it.Valid() || it.Key() != 20 {
+		t.Errorf("SeekLE(20) = (%v, %v), want (20, true)", it.Key(), it.Valid())
+	}
+	if it := s.SeekLE(5); it.Valid() {
+		t.Errorf("SeekLE(5) = valid, want invalid")
+	}
+}
+
+func TestIterator_Close(t *testing.T) {
+	s := New[int, string]()
+	s.Put(1, "one")
+
+	it := s.Iterator()
+	it.Close()
+
+	if it.Next() {
+		t.Error("Next should return false after Close")
+	}
+	if it.Valid() {
+		t.Error("Valid should return false after Close")
+	}
+}
+
+func TestIterator_KeyValuePanicWhenInvalid(t *testing.T) {
+	s := New[int, string]()
+	s.Put(1, "one")
+
+	it := s.Iterator()
+	defer func() {
+		if recover() == nil {
+			t.Error("Key should panic before the first Next")
+		}
+	}()
+	it.Key()
+}
+
+func TestSkipMap_KeysAndEntries(t *testing.T) {
+	s := New[int, string]()
+	s.Put(3, "c")
+	s.Put(1, "a")
+	s.Put(2, "b")
+
+	keys := s.Keys()
+	if len(keys) != 3 || keys[0] != 1 || keys[1] != 2 || keys[2] != 3 {
+		t.Errorf("Keys() = %v, want [1 2 3]", keys)
+	}
+
+	entries := s.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Entries() returned %d entries, want 3", len(entries))
+	}
+	expectedValues := []string{"a", "b", "c"}
+	for i, e := range entries {
+		if e.Key != i+1 || e.Value != expectedValues[i] {
+			t.Errorf("entries[%d] = %+v, want key %d value %q", i, e, i+1, expectedValues[i])
+		}
+	}
+}
+
+func TestIterator_ConcurrentScanDuringWrites(t *testing.T) {
+	s := New[int, int]()
+	for i := 0; i < 200; i++ {
+		s.Put(i, i)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 200; i < 400; i++ {
+			s.Put(i, i)
+		}
+		for i := 0; i < 100; i++ {
+			s.Remove(i)
+		}
+	}()
+
+	it := s.Iterator()
+	seen := 0
+	for it.Next() {
+		seen++
+	}
+	it.Close()
+	wg.Wait()
+
+	if seen == 0 {
+		t.Error("iterator observed no entries during concurrent writes")
+	}
+}
@@ -0,0 +1,96 @@
+package skipmap
+
+// rankOfFirstGE returns the 0-based rank of the first live entry with a key
+// greater than or equal to key (equivalently, the number of live entries
+// with a key strictly less than key).
+func (s *SkipMap[K, V]) rankOfFirstGE(key K) int64 {
+	x := s.header
+	rank := int64(0)
+
+	for level := s.maxLevel - 1; level >= 0; level-- {
+		for {
+			next := x.forward[level].Load()
+			if next == nil || s.comparator(next.key, key) >= 0 {
+				break
+			}
+			rank += x.span[level].Load()
+			x = next
+		}
+	}
+	return rank
+}
+
+// rankOfFirstGT returns the 0-based rank of the first live entry with a key
+// strictly greater than key (equivalently, the number of live entries with
+// a key less than or equal to key).
+func (s *SkipMap[K, V]) rankOfFirstGT(key K) int64 {
+	x := s.header
+	rank := int64(0)
+
+	for level := s.maxLevel - 1; level >= 0; level-- {
+		for {
+			next := x.forward[level].Load()
+			if next == nil || s.comparator(next.key, key) > 0 {
+				break
+			}
+			rank += x.span[level].Load()
+			x = next
+		}
+	}
+	return rank
+}
+
+// Rank returns the 0-based index of key in ascending sorted order, and
+// whether key is present. It runs in O(log n) using the span counters
+// maintained alongside forward, rather than materializing a Range.
+func (s *SkipMap[K, V]) Rank(key K) (int, bool) {
+	if _, ok := s.Get(key); !ok {
+		return 0, false
+	}
+	return int(s.rankOfFirstGE(key)), true
+}
+
+// Select returns the i-th smallest entry (0-based), walking top-down and
+// accumulating span counts until they reach i+1, rather than scanning i
+// entries one at a time.
+func (s *SkipMap[K, V]) Select(i int) (K, V, bool) {
+	var zeroK K
+	var zeroV V
+	if i < 0 {
+		return zeroK, zeroV, false
+	}
+
+	target := int64(i + 1)
+	traversed := int64(0)
+	x := s.header
+
+	for level := s.maxLevel - 1; level >= 0; level-- {
+		for {
+			next := x.forward[level].Load()
+			if next == nil || traversed+x.span[level].Load() > target {
+				break
+			}
+			traversed += x.span[level].Load()
+			x = next
+		}
+		if traversed == target {
+			break
+		}
+	}
+
+	if traversed != target || x == s.header || !x.fullyLinked.Load() || x.marked.Load() {
+		return zeroK, zeroV, false
+	}
+	return x.key, *x.value.Load(), true
+}
+
+// CountRange returns the number of live entries with a key in [start, end],
+// computed from span counters in O(log n) without materializing them.
+func (s *SkipMap[K, V]) CountRange(start, end K) int {
+	lo := s.rankOfFirstGE(start)
+	hi := s.rankOfFirstGT(end)
+	if hi < lo {
+		return 0
+	}
+	return int(hi - lo)
+}
@@ -0,0 +1,125 @@
+package skipmap
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSkipMap_RankAndSelect(t *testing.T) {
+	s := New[int, string]()
+	for i := 0; i < 20; i += 2 {
+		s.Put(i, fmt.Sprintf("val%d", i))
+	}
+
+	if rank, ok := s.Rank(0); !ok || rank != 0 {
+		t.Errorf("Rank(0) = (%d, %v), want (0, true)", rank, ok)
+	}
+	if rank, ok := s.Rank(10); !ok || rank != 5 {
+		t.Errorf("Rank(10) = (%d, %v), want (5, true)", rank, ok)
+	}
+	if rank, ok := s.Rank(18); !ok || rank != 9 {
+		t.Errorf("Rank(18) = (%d, %v), want (9, true)", rank, ok)
+	}
+	if _, ok := s.Rank(7); ok {
+		t.Error("Rank(7) reported found for a key that was never inserted")
+	}
+
+	if k, v, ok := s.Select(0); !ok || k != 0 || v != "val0" {
+		t.Errorf("Select(0) = (%d, %q, %v), want (0, \"val0\", true)", k, v, ok)
+	}
+	if k, v, ok := s.Select(5); !ok || k != 10 || v != "val10" {
+		t.Errorf("Select(5) = (%d, %q, %v), want (10, \"val10\", true)", k, v, ok)
+	}
+	if k, v, ok := s.Select(9); !ok || k != 18 || v != "val18" {
+		t.Errorf("Select(9) = (%d, %q, %v), want (18, \"val18\", true)", k, v, ok)
+	}
+	if _, _, ok := s.Select(10); ok {
+		t.Error("Select(10) should be out of range for a 10-entry map")
+	}
+	if _, _, ok := s.Select(-1); ok {
+		t.Error("Select(-1) should be invalid")
+	}
+}
+
+func TestSkipMap_RankSelectAfterRemove(t *testing.T) {
+	s := New[int, string]()
+	for i := 0; i < 10; i++ {
+		s.Put(i, fmt.Sprintf("val%d", i))
+	}
+	s.Remove(3)
+	s.Remove(4)
+
+	if rank, ok := s.Rank(5); !ok || rank != 3 {
+		t.Errorf("Rank(5) after removing 3 and 4 = (%d, %v), want (3, true)", rank, ok)
+	}
+	if k, _, ok := s.Select(3); !ok || k != 5 {
+		t.Errorf("Select(3) after removing 3 and 4 = (%d, %v), want (5, true)", k, ok)
+	}
+	if s.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", s.Len())
+	}
+}
+
+func TestSkipMap_CountRange(t *testing.T) {
+	s := New[int, string]()
+	for i := 0; i < 100; i++ {
+		s.Put(i, "v")
+	}
+
+	if count := s.CountRange(10, 20); count != 11 {
+		t.Errorf("CountRange(10, 20) = %d, want 11", count)
+	}
+	if count := s.CountRange(0, 99); count != 100 {
+		t.Errorf("CountRange(0, 99) = %d, want 100", count)
+	}
+	if count := s.CountRange(200, 300); count != 0 {
+		t.Errorf("CountRange(200, 300) = %d, want 0", count)
+	}
+	if count := s.CountRange(95, 150); count != 5 {
+		t.Errorf("CountRange(95, 150) = %d, want 5", count)
+	}
+}
+
+// TestSkipMap_RankConcurrentWithWrites exercises Rank/Select/CountRange
+// alongside concurrent Put/Remove; span bookkeeping is only eventually
+// consistent under concurrent writers at every level, not just above a
+// node's own topLevel (see the span field's doc comment on Node), so this
+// only checks the map settles back to a consistent state once the writers
+// finish, not that every query mid-flight is exact.
+func TestSkipMap_RankConcurrentWithWrites(t *testing.T) {
+	s := New[int, int]()
+	const n = 500
+	for i := 0; i < n; i++ {
+		s.Put(i, i)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(gID int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := n + gID*100 + i
+				s.Put(key, key)
+				s.Rank(key)
+				s.Select(i)
+				s.CountRange(0, n)
+				s.Remove(key)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if s.Len() != n {
+		t.Errorf("Len() = %d after concurrent writers finished, want %d", s.Len(), n)
+	}
+	for i := 0; i < n; i++ {
+		if rank, ok := s.Rank(i); !ok || rank != i {
+			t.Errorf("Rank(%d) = (%d, %v) after writers settled, want (%d, true)", i, rank, ok, i)
+		}
+	}
+	if count := s.CountRange(0, n-1); count != n {
+		t.Errorf("CountRange(0, %d) = %d after writers settled, want %d", n-1, count, n)
+	}
+}
@@ -0,0 +1,154 @@
+package skipmap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync/atomic"
+)
+
+// MarshalJSON encodes the map as a JSON array of {"Key":...,"Value":...}
+// entries in ascending key order. K and V must themselves be marshalable.
+func (s *SkipMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Entries())
+}
+
+// UnmarshalJSON replaces the map's contents with the entries encoded by
+// MarshalJSON. The receiver must already be constructed via New or
+// NewWithComparator (its comparator and level parameters cannot be
+// recovered from the JSON itself).
+func (s *SkipMap[K, V]) UnmarshalJSON(data []byte) error {
+	if err := s.requireConstructed(); err != nil {
+		return err
+	}
+	var entries []Entry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	s.bulkLoad(entries)
+	return nil
+}
+
+// GobEncode encodes the map in the same format as WriteSnapshot.
+func (s *SkipMap[K, V]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := s.WriteSnapshot(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the map's contents by reading the format written by
+// GobEncode/WriteSnapshot. As with UnmarshalJSON, the receiver must already
+// be constructed via New or NewWithComparator.
+func (s *SkipMap[K, V]) GobDecode(data []byte) error {
+	return s.LoadSnapshot(bytes.NewReader(data))
+}
+
+// WriteSnapshot writes every entry to w in ascending key order: a varint
+// entry count, followed by each Entry[K, V] gob-encoded in turn. It collects
+// the entries with Entries, which takes no lock: the stream is not a
+// point-in-time snapshot, and entries inserted or removed while the call
+// runs may or may not be included.
+func (s *SkipMap[K, V]) WriteSnapshot(w io.Writer) error {
+	entries := s.Entries()
+
+	countBuf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(countBuf, int64(len(entries)))
+	if _, err := w.Write(countBuf[:n]); err != nil {
+		return fmt.Errorf("skipmap: writing snapshot count: %w", err)
+	}
+
+	enc := gob.NewEncoder(w)
+	for i, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("skipmap: writing snapshot entry %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// LoadSnapshot replaces the map's contents by reading the format written by
+// WriteSnapshot, bulk-building the skip list in a single O(n) pass (per-level
+// tail pointers) rather than one O(log n) Put per entry. The receiver must
+// already be constructed via New or NewWithComparator.
+func (s *SkipMap[K, V]) LoadSnapshot(r io.Reader) error {
+	if err := s.requireConstructed(); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(r)
+	count, err := binary.ReadVarint(br)
+	if err != nil {
+		return fmt.Errorf("skipmap: reading snapshot count: %w", err)
+	}
+	if count < 0 {
+		return errors.New("skipmap: corrupt snapshot: negative entry count")
+	}
+
+	entries := make([]Entry[K, V], 0, count)
+	dec := gob.NewDecoder(br)
+	for i := int64(0); i < count; i++ {
+		var e Entry[K, V]
+		if err := dec.Decode(&e); err != nil {
+			return fmt.Errorf("skipmap: corrupt snapshot: reading entry %d: %w", i, err)
+		}
+		entries = append(entries, e)
+	}
+
+	s.bulkLoad(entries)
+	return nil
+}
+
+func (s *SkipMap[K, V]) requireConstructed() error {
+	if s.comparator == nil {
+		return errors.New("skipmap: map must be constructed with New or NewWithComparator before loading into it")
+	}
+	return nil
+}
+
+// bulkLoad discards the map's current contents and rebuilds it from
+// entries in a single linear pass: entries is sorted once, then each node
+// is appended to a per-level tail pointer (the classic bulk-load used to
+// restore a sorted on-disk format without paying the O(log n) search cost
+// of a Put per entry).
+func (s *SkipMap[K, V]) bulkLoad(entries []Entry[K, V]) {
+	sort.Slice(entries, func(i, j int) bool {
+		return s.comparator(entries[i].Key, entries[j].Key) < 0
+	})
+
+	s.header = &Node[K, V]{
+		forward: make([]atomic.Pointer[Node[K, V]], s.maxLevel),
+		span:    make([]atomic.Int64, s.maxLevel),
+	}
+
+	tails := make([]*Node[K, V], s.maxLevel)
+	lastIdx := make([]int64, s.maxLevel)
+	for i := range tails {
+		tails[i] = s.header
+		lastIdx[i] = -1
+	}
+
+	prev := s.header
+	for pos, e := range entries {
+		level := s.randomLevel()
+		node := newNode(e.Key, e.Value, level)
+		node.back.Store(prev)
+		for i := 0; i <= level; i++ {
+			tails[i].span[i].Store(int64(pos) - lastIdx[i])
+			tails[i].forward[i].Store(node)
+			tails[i] = node
+			lastIdx[i] = int64(pos)
+		}
+		node.fullyLinked.Store(true)
+		prev = node
+	}
+
+	s.length.Store(int64(len(entries)))
+}
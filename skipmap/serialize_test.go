@@ -0,0 +1,129 @@
+package skipmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSkipMap_JSONRoundTrip(t *testing.T) {
+	s := New[int, string]()
+	for i := 0; i < 50; i++ {
+		s.Put(i, fmt.Sprintf("val%d", i))
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	loaded := New[int, string]()
+	if err := json.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+
+	if loaded.Len() != s.Len() {
+		t.Fatalf("loaded.Len() = %d, want %d", loaded.Len(), s.Len())
+	}
+	for i := 0; i < 50; i++ {
+		v, ok := loaded.Get(i)
+		want := fmt.Sprintf("val%d", i)
+		if !ok || v != want {
+			t.Errorf("loaded.Get(%d) = (%q, %v), want (%q, true)", i, v, ok, want)
+		}
+	}
+	for i, k := range loaded.Keys() {
+		if k != i {
+			t.Errorf("loaded.Keys()[%d] = %d, want %d (order not preserved)", i, k, i)
+		}
+	}
+}
+
+func TestSkipMap_UnmarshalJSONRequiresConstructedMap(t *testing.T) {
+	var s SkipMap[int, string]
+	err := json.Unmarshal([]byte(`[]`), &s)
+	if err == nil {
+		t.Fatal("UnmarshalJSON on a zero-value SkipMap should report an error")
+	}
+}
+
+func TestSkipMap_GobRoundTrip(t *testing.T) {
+	s := New[int, int]()
+	for i := 0; i < 50; i++ {
+		s.Put(i, i*i)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		t.Fatalf("gob encode: %v", err)
+	}
+
+	loaded := New[int, int]()
+	if err := gob.NewDecoder(&buf).Decode(loaded); err != nil {
+		t.Fatalf("gob decode: %v", err)
+	}
+
+	if loaded.Len() != s.Len() {
+		t.Fatalf("loaded.Len() = %d, want %d", loaded.Len(), s.Len())
+	}
+	for i := 0; i < 50; i++ {
+		v, ok := loaded.Get(i)
+		if !ok || v != i*i {
+			t.Errorf("loaded.Get(%d) = (%d, %v), want (%d, true)", i, v, ok, i*i)
+		}
+	}
+}
+
+func TestSkipMap_WriteLoadSnapshot(t *testing.T) {
+	s := New[string, int]()
+	words := []string{"pear", "apple", "cherry", "banana", "date"}
+	for i, w := range words {
+		s.Put(w, i)
+	}
+	s.Remove("date")
+
+	var buf bytes.Buffer
+	if err := s.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+
+	loaded := New[string, int]()
+	if err := loaded.LoadSnapshot(&buf); err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+
+	if loaded.Len() != s.Len() {
+		t.Fatalf("loaded.Len() = %d, want %d", loaded.Len(), s.Len())
+	}
+	expectedKeys := []string{"apple", "banana", "cherry", "pear"}
+	if keys := loaded.Keys(); !equalStringSlices(keys, expectedKeys) {
+		t.Errorf("loaded.Keys() = %v, want %v", keys, expectedKeys)
+	}
+	for rank, k := range expectedKeys {
+		if got, ok := loaded.Rank(k); !ok || got != rank {
+			t.Errorf("loaded.Rank(%q) = (%d, %v), want (%d, true)", k, got, ok, rank)
+		}
+	}
+}
+
+func TestSkipMap_LoadSnapshotCorruptStream(t *testing.T) {
+	s := New[int, string]()
+	if err := s.LoadSnapshot(strings.NewReader("not a snapshot")); err == nil {
+		t.Fatal("LoadSnapshot on a corrupt stream should return an error")
+	}
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package skipmap
+
+import "cmp"
+
+// DefaultShardCount is the number of shards NewSharded uses when shards <= 0
+// is passed.
+const DefaultShardCount = 32
+
+// ShardedSkipMap partitions keys across N independent SkipMap shards, each
+// guarded by its own per-node locks, to reduce contention on writes under a
+// write-heavy workload. Sharding trades away the single SkipMap's global
+// sorted order: Range is intentionally not provided here (iterate a single
+// shard via Shard, or use MergedRange for a globally sorted view).
+type ShardedSkipMap[K cmp.Ordered, V any] struct {
+	shards []*SkipMap[K, V]
+	hasher func(K) uint64
+}
+
+// NewSharded creates a ShardedSkipMap with the given number of shards
+// (DefaultShardCount if shards <= 0), using hasher to pick a key's shard.
+func NewSharded[K cmp.Ordered, V any](shards int, hasher func(K) uint64) *ShardedSkipMap[K, V] {
+	if shards <= 0 {
+		shards = DefaultShardCount
+	}
+
+	m := &ShardedSkipMap[K, V]{
+		shards: make([]*SkipMap[K, V], shards),
+		hasher: hasher,
+	}
+	for i := range m.shards {
+		m.shards[i] = New[K, V]()
+	}
+	return m
+}
+
+// Shard returns the SkipMap that key hashes to, for callers that need to
+// batch several operations on the same key under that shard's own locking.
+func (m *ShardedSkipMap[K, V]) Shard(key K) *SkipMap[K, V] {
+	return m.shards[m.hasher(key)%uint64(len(m.shards))]
+}
+
+func (m *ShardedSkipMap[K, V]) Put(key K, value V) {
+	m.Shard(key).Put(key, value)
+}
+
+func (m *ShardedSkipMap[K, V]) Get(key K) (V, bool) {
+	return m.Shard(key).Get(key)
+}
+
+func (m *ShardedSkipMap[K, V]) Remove(key K) bool {
+	return m.Shard(key).Remove(key)
+}
+
+// Len returns the total number of entries across all shards.
+func (m *ShardedSkipMap[K, V]) Len() int {
+	total := 0
+	for _, shard := range m.shards {
+		total += shard.Len()
+	}
+	return total
+}
+
+// RangeAll calls f for every entry in the map, shard by shard. Keys are
+// sorted within a shard but the shards themselves are visited in no
+// particular global order; use MergedRange for a globally sorted view.
+// Iteration stops across all shards as soon as f returns false.
+func (m *ShardedSkipMap[K, V]) RangeAll(f func(key K, value V) bool) {
+	for _, shard := range m.shards {
+		stop := false
+		shard.forEach(func(key K, value V) bool {
+			if !f(key, value) {
+				stop = true
+				return false
+			}
+			return true
+		})
+		if stop {
+			return
+		}
+	}
+}
+
+// shardCursor walks one shard's already-sorted [start, end] slice for the
+// k-way merge performed by MergedRange.
+type shardCursor[K cmp.Ordered, V any] struct {
+	keys []K
+	vals []V
+	pos  int
+}
+
+func (c *shardCursor[K, V]) exhausted() bool {
+	return c.pos >= len(c.keys)
+}
+
+// Entry is a single key-value pair, as returned by MergedRange.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// MergedRange returns every entry with a key in [start, end], across all
+// shards, in globally sorted key order. It gathers each shard's matching
+// entries (already sorted, since a single shard is a regular SkipMap) and
+// then performs a k-way merge over the per-shard cursors, repeatedly taking
+// the smallest head key among the shards still in play.
+func (m *ShardedSkipMap[K, V]) MergedRange(start, end K) []Entry[K, V] {
+	cursors := make([]*shardCursor[K, V], 0, len(m.shards))
+	total := 0
+	for _, shard := range m.shards {
+		c := &shardCursor[K, V]{}
+		shard.RangeFunc(start, end, func(key K, value V) bool {
+			c.keys = append(c.keys, key)
+			c.vals = append(c.vals, value)
+			return true
+		})
+		if len(c.keys) > 0 {
+			cursors = append(cursors, c)
+			total += len(c.keys)
+		}
+	}
+
+	merged := make([]Entry[K, V], 0, total)
+	h := &cursorHeap[K, V]{cursors: cursors}
+	heapInit(h)
+	for h.Len() > 0 {
+		c := h.cursors[0]
+		merged = append(merged, Entry[K, V]{Key: c.keys[c.pos], Value: c.vals[c.pos]})
+		c.pos++
+		if c.exhausted() {
+			heapPop(h)
+		} else {
+			heapFix(h, 0)
+		}
+	}
+	return merged
+}
+
+// cursorHeap is a minimal binary min-heap over shard cursors, ordered by
+// each cursor's current head key. It implements just the operations
+// MergedRange needs rather than pulling in container/heap for three calls.
+type cursorHeap[K cmp.Ordered, V any] struct {
+	cursors []*shardCursor[K, V]
+}
+
+func (h *cursorHeap[K, V]) Len() int { return len(h.cursors) }
+
+func (h *cursorHeap[K, V]) less(i, j int) bool {
+	return h.cursors[i].keys[h.cursors[i].pos] < h.cursors[j].keys[h.cursors[j].pos]
+}
+
+func (h *cursorHeap[K, V]) swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+func heapInit[K cmp.Ordered, V any](h *cursorHeap[K, V]) {
+	n := h.Len()
+	for i := n/2 - 1; i >= 0; i-- {
+		siftDown(h, i, n)
+	}
+}
+
+func heapPop[K cmp.Ordered, V any](h *cursorHeap[K, V]) {
+	n := h.Len() - 1
+	h.swap(0, n)
+	h.cursors = h.cursors[:n]
+	siftDown(h, 0, n)
+}
+
+// heapFix restores heap order after the item at i has increased (its
+// cursor advanced to a new, larger head key).
+func heapFix[K cmp.Ordered, V any](h *cursorHeap[K, V], i int) {
+	siftDown(h, i, h.Len())
+}
+
+func siftDown[K cmp.Ordered, V any](h *cursorHeap[K, V], i, n int) {
+	for {
+		left := 2*i + 1
+		if left >= n {
+			return
+		}
+		smallest := left
+		if right := left + 1; right < n && h.less(right, left) {
+			smallest = right
+		}
+		if !h.less(smallest, i) {
+			return
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
@@ -0,0 +1,123 @@
+package skipmap
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func fnv64Hash(s string) uint64 {
+	var h uint64 = 14695981039346656037
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+func TestShardedSkipMap_PutGetRemove(t *testing.T) {
+	m := NewSharded[string, int](8, fnv64Hash)
+
+	for i := 0; i < 100; i++ {
+		m.Put(fmt.Sprintf("key%d", i), i)
+	}
+	if m.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", m.Len())
+	}
+
+	if v, ok := m.Get("key42"); !ok || v != 42 {
+		t.Errorf("Get(\"key42\") = (%d, %v), want (42, true)", v, ok)
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("Get(\"missing\") reported found, want not found")
+	}
+
+	if !m.Remove("key42") {
+		t.Error("Remove(\"key42\") = false, want true")
+	}
+	if _, ok := m.Get("key42"); ok {
+		t.Error("key42 still present after Remove")
+	}
+	if m.Len() != 99 {
+		t.Errorf("Len() = %d after Remove, want 99", m.Len())
+	}
+}
+
+func TestShardedSkipMap_DefaultShardCount(t *testing.T) {
+	m := NewSharded[string, int](0, fnv64Hash)
+	if len(m.shards) != DefaultShardCount {
+		t.Errorf("len(shards) = %d, want DefaultShardCount (%d)", len(m.shards), DefaultShardCount)
+	}
+}
+
+func TestShardedSkipMap_Shard(t *testing.T) {
+	m := NewSharded[string, int](8, fnv64Hash)
+	m.Put("key1", 1)
+
+	shard := m.Shard("key1")
+	if v, ok := shard.Get("key1"); !ok || v != 1 {
+		t.Errorf("Shard(\"key1\").Get(\"key1\") = (%d, %v), want (1, true)", v, ok)
+	}
+}
+
+func TestShardedSkipMap_RangeAll(t *testing.T) {
+	m := NewSharded[int, string](8, func(k int) uint64 { return uint64(k) })
+	for i := 0; i < 20; i++ {
+		m.Put(i, fmt.Sprintf("val%d", i))
+	}
+
+	seen := make(map[int]string)
+	m.RangeAll(func(key int, value string) bool {
+		seen[key] = value
+		return true
+	})
+	if len(seen) != 20 {
+		t.Errorf("RangeAll visited %d entries, want 20", len(seen))
+	}
+	for i := 0; i < 20; i++ {
+		if seen[i] != fmt.Sprintf("val%d", i) {
+			t.Errorf("RangeAll missed or mangled key %d: got %q", i, seen[i])
+		}
+	}
+}
+
+func TestShardedSkipMap_MergedRange(t *testing.T) {
+	m := NewSharded[int, string](4, func(k int) uint64 { return uint64(k) })
+	for i := 0; i < 30; i++ {
+		m.Put(i, fmt.Sprintf("val%d", i))
+	}
+
+	entries := m.MergedRange(5, 15)
+	if len(entries) != 11 {
+		t.Fatalf("MergedRange(5, 15) returned %d entries, want 11", len(entries))
+	}
+	for i, e := range entries {
+		wantKey := 5 + i
+		if e.Key != wantKey || e.Value != fmt.Sprintf("val%d", wantKey) {
+			t.Errorf("entries[%d] = %+v, want key %d", i, e, wantKey)
+		}
+	}
+}
+
+func TestShardedSkipMap_ConcurrentAccess(t *testing.T) {
+	m := NewSharded[int, int](16, func(k int) uint64 { return uint64(k) })
+	var wg sync.WaitGroup
+	const numGoroutines = 50
+	const numKeys = 20
+
+	for g := 0; g < numGoroutines; g++ {
+		wg.Add(1)
+		go func(gID int) {
+			defer wg.Done()
+			for i := 0; i < numKeys; i++ {
+				m.Put(i, gID)
+				m.Get(i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if m.Len() != numKeys {
+		t.Errorf("Len() = %d, want %d", m.Len(), numKeys)
+	}
+}
@@ -3,7 +3,9 @@ package skipmap
 import (
 	"cmp"
 	"math/rand/v2"
+	"runtime"
 	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -11,22 +13,68 @@ const (
 	DefaultProbability = 0.5
 )
 
+// Node is a single entry in the skip list. Forward links are stored as
+// atomic pointers so that Get can traverse the list without taking any
+// lock. Structural changes (linking/unlinking a node) are made under the
+// per-node mutex of the nodes being modified, following the optimistic
+// lock-free skip list of Herlihy & Shavit: a node is only considered part
+// of the list once fullyLinked is set, and marked flags a logically
+// deleted node before it is physically unlinked.
 type Node[K any, V any] struct {
-	key     K
-	value   V
-	forward []*Node[K, V]
+	key      K
+	value    atomic.Pointer[V]
+	topLevel int
+	forward  []atomic.Pointer[Node[K, V]]
+
+	// span[i] is the number of level-0 nodes that forward[i] skips over,
+	// enabling the O(log n) order-statistic queries in rank.go. At levels
+	// <= the inserted/removed node's topLevel, span and forward are both
+	// written under the same per-level predecessor locks used to make the
+	// structural change, which serializes that write against other
+	// writers; at levels above that, where no structural change is being
+	// made, span is instead adjusted lock-free with Add. Either way, span
+	// and forward are still two independent atomic fields with no combined
+	// snapshot, so a lock-free rank/select query (which takes no locks at
+	// all) can race a writer at any level, not just above topLevel, and
+	// observe one field updated and not the other — e.g. a span already
+	// reflecting an insert while forward still points at the old
+	// successor. Such a query's answer can be wrong for that one call, not
+	// merely stale; it self-corrects on the next query once the write
+	// completes, which is the trade-off accepted here to avoid
+	// reintroducing a global lock on the header node.
+	span []atomic.Int64
+
+	// back links the bottom level only, doubly-linking it so an Iterator
+	// can walk backward with Prev. It is maintained best-effort alongside
+	// forward[0]: a concurrent insert/remove may leave it briefly stale,
+	// so a long-lived Iterator can occasionally skip a node that was
+	// inserted behind it mid-scan. A fresh Iterator always sees a
+	// consistent view once the structural change has completed.
+	back atomic.Pointer[Node[K, V]]
+
+	mu          sync.Mutex
+	marked      atomic.Bool
+	fullyLinked atomic.Bool
+}
+
+func newNode[K any, V any](key K, value V, topLevel int) *Node[K, V] {
+	n := &Node[K, V]{
+		key:      key,
+		topLevel: topLevel,
+		forward:  make([]atomic.Pointer[Node[K, V]], topLevel+1),
+		span:     make([]atomic.Int64, topLevel+1),
+	}
+	n.value.Store(&value)
+	return n
 }
 
 type SkipMap[K any, V any] struct {
 	header      *Node[K, V]
 	maxLevel    int
-	level       int
-	length      int
 	probability float32
-	mu          sync.RWMutex
 	comparator  func(a, b K) int
-
-	updateCache []*Node[K, V]
+	equal       func(a, b V) bool
+	length      atomic.Int64
 }
 
 func New[K cmp.Ordered, V any]() *SkipMap[K, V] {
@@ -35,16 +83,30 @@ func New[K cmp.Ordered, V any]() *SkipMap[K, V] {
 
 func NewWithComparator[K any, V any](comparator func(a, b K) int) *SkipMap[K, V] {
 	return &SkipMap[K, V]{
-		header:      &Node[K, V]{forward: make([]*Node[K, V], DefaultMaxLevel)},
+		header: &Node[K, V]{
+			forward: make([]atomic.Pointer[Node[K, V]], DefaultMaxLevel),
+			span:    make([]atomic.Int64, DefaultMaxLevel),
+		},
 		maxLevel:    DefaultMaxLevel,
-		level:       0,
-		length:      0,
 		probability: DefaultProbability,
 		comparator:  comparator,
-		updateCache: make([]*Node[K, V], DefaultMaxLevel),
 	}
 }
 
+// NewWithComparatorAndEquality is like NewWithComparator but also accepts an
+// equality function for V, required by CompareAndSwap and CompareAndDelete.
+func NewWithComparatorAndEquality[K any, V any](comparator func(a, b K) int, equal func(a, b V) bool) *SkipMap[K, V] {
+	s := NewWithComparator[K, V](comparator)
+	s.equal = equal
+	return s
+}
+
+// NewComparable is a convenience constructor for ordered keys and comparable
+// values, wiring up NewWithComparatorAndEquality with cmp.Compare and ==.
+func NewComparable[K cmp.Ordered, V comparable]() *SkipMap[K, V] {
+	return NewWithComparatorAndEquality[K, V](cmp.Compare[K], func(a, b V) bool { return a == b })
+}
+
 func defaultComparator[K cmp.Ordered](a, b K) int {
 	if a < b {
 		return -1
@@ -63,199 +125,473 @@ func (s *SkipMap[K, V]) randomLevel() int {
 	return level
 }
 
-func (s *SkipMap[K, V]) Put(key K, value V) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// find locates key and fills preds/succs (which must have length s.maxLevel)
+// with, for every level, the last node known to precede key and the node
+// that currently follows it. It returns the lowest level at which a node
+// with a matching key was observed, or -1 if none was found.
+func (s *SkipMap[K, V]) find(key K, preds, succs []*Node[K, V]) int {
+	lFound := -1
+	pred := s.header
+
+	for i := s.maxLevel - 1; i >= 0; i-- {
+		curr := pred.forward[i].Load()
+		for curr != nil && s.comparator(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.forward[i].Load()
+		}
+		if lFound == -1 && curr != nil && s.comparator(curr.key, key) == 0 {
+			lFound = i
+		}
+		preds[i] = pred
+		succs[i] = curr
+	}
 
-	update := s.updateCache
-	current := s.header
+	return lFound
+}
 
-	for i := s.level; i >= 0; i-- {
-		for current.forward[i] != nil && s.comparator(current.forward[i].key, key) < 0 {
-			current = current.forward[i]
+// findRanked is find plus, for every level, the position of preds[i] on the
+// bottom level (the number of entries up to and including it, so 0 for the
+// header and 1 for the first entry), needed by Put to split
+// span counts correctly when linking in a new node. See rank.go.
+func (s *SkipMap[K, V]) findRanked(key K, preds, succs []*Node[K, V], rank []int64) int {
+	lFound := -1
+	pred := s.header
+
+	for i := s.maxLevel - 1; i >= 0; i-- {
+		if i == s.maxLevel-1 {
+			rank[i] = 0
+		} else {
+			rank[i] = rank[i+1]
 		}
-		update[i] = current
+
+		curr := pred.forward[i].Load()
+		for curr != nil && s.comparator(curr.key, key) < 0 {
+			rank[i] += pred.span[i].Load()
+			pred = curr
+			curr = pred.forward[i].Load()
+		}
+		if lFound == -1 && curr != nil && s.comparator(curr.key, key) == 0 {
+			lFound = i
+		}
+		preds[i] = pred
+		succs[i] = curr
 	}
 
-	current = current.forward[0]
+	return lFound
+}
+
+func (s *SkipMap[K, V]) Put(key K, value V) {
+	topLevel := s.randomLevel()
+	preds := make([]*Node[K, V], s.maxLevel)
+	succs := make([]*Node[K, V], s.maxLevel)
+	rank := make([]int64, s.maxLevel)
+
+	for {
+		lFound := s.findRanked(key, preds, succs, rank)
+		if lFound != -1 {
+			found := succs[lFound]
+			if found.marked.Load() {
+				continue
+			}
+			waitFullyLinked(found)
+			found.mu.Lock()
+			if found.marked.Load() {
+				found.mu.Unlock()
+				continue
+			}
+			v := value
+			found.value.Store(&v)
+			found.mu.Unlock()
+			return
+		}
 
-	if current != nil && s.comparator(current.key, key) == 0 {
-		current.value = value
-		return
+		if s.tryLink(key, value, topLevel, preds, succs, rank) {
+			return
+		}
 	}
+}
 
-	newLevel := s.randomLevel()
+// waitFullyLinked spins until a node concurrently being inserted by another
+// goroutine has finished being linked in at all of its levels.
+func waitFullyLinked[K any, V any](n *Node[K, V]) {
+	for !n.fullyLinked.Load() {
+		runtime.Gosched()
+	}
+}
 
-	if newLevel > s.level {
-		for i := s.level + 1; i <= newLevel; i++ {
-			update[i] = s.header
+// LoadOrStore returns the existing value for key if present; otherwise it
+// stores and returns value. The loaded result reports whether value was
+// already present. The whole operation is a single critical section: no
+// other goroutine can observe the key as absent and then present without
+// also observing this insert.
+func (s *SkipMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	topLevel := s.randomLevel()
+	preds := make([]*Node[K, V], s.maxLevel)
+	succs := make([]*Node[K, V], s.maxLevel)
+	rank := make([]int64, s.maxLevel)
+
+	for {
+		lFound := s.findRanked(key, preds, succs, rank)
+		if lFound != -1 {
+			found := succs[lFound]
+			if found.marked.Load() {
+				continue
+			}
+			waitFullyLinked(found)
+			return *found.value.Load(), true
 		}
-		s.level = newLevel
-	}
 
-	newNode := &Node[K, V]{
-		key:     key,
-		value:   value,
-		forward: make([]*Node[K, V], newLevel+1),
+		if s.tryLink(key, value, topLevel, preds, succs, rank) {
+			return value, false
+		}
 	}
+}
+
+// Swap stores value for key and returns the value it replaced, if any.
+func (s *SkipMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	topLevel := s.randomLevel()
+	preds := make([]*Node[K, V], s.maxLevel)
+	succs := make([]*Node[K, V], s.maxLevel)
+	rank := make([]int64, s.maxLevel)
+
+	for {
+		lFound := s.findRanked(key, preds, succs, rank)
+		if lFound != -1 {
+			found := succs[lFound]
+			if found.marked.Load() {
+				continue
+			}
+			waitFullyLinked(found)
+			found.mu.Lock()
+			if found.marked.Load() {
+				found.mu.Unlock()
+				continue
+			}
+			previous = *found.value.Load()
+			v := value
+			found.value.Store(&v)
+			found.mu.Unlock()
+			return previous, true
+		}
 
-	for i := 0; i <= newLevel; i++ {
-		newNode.forward[i] = update[i].forward[i]
-		update[i].forward[i] = newNode
+		if s.tryLink(key, value, topLevel, preds, succs, rank) {
+			var zero V
+			return zero, false
+		}
 	}
+}
 
-	s.length++
+// CompareAndSwap stores new for key only if the current value equals old,
+// as reported by the equality function the map was constructed with (see
+// NewWithComparatorAndEquality and NewComparable). It reports whether the
+// swap took place.
+func (s *SkipMap[K, V]) CompareAndSwap(key K, old, new V) bool {
+	s.requireEqual()
+	preds := make([]*Node[K, V], s.maxLevel)
+	succs := make([]*Node[K, V], s.maxLevel)
+
+	for {
+		lFound := s.find(key, preds, succs)
+		if lFound == -1 {
+			return false
+		}
+		found := succs[lFound]
+		if found.marked.Load() {
+			continue
+		}
+		waitFullyLinked(found)
+		found.mu.Lock()
+		if found.marked.Load() {
+			found.mu.Unlock()
+			continue
+		}
+		if !s.equal(*found.value.Load(), old) {
+			found.mu.Unlock()
+			return false
+		}
+		v := new
+		found.value.Store(&v)
+		found.mu.Unlock()
+		return true
+	}
 }
 
-func (s *SkipMap[K, V]) Get(key K) (V, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+func (s *SkipMap[K, V]) requireEqual() {
+	if s.equal == nil {
+		panic("skipmap: CompareAndSwap/CompareAndDelete require an equality function; construct the map with NewWithComparatorAndEquality or NewComparable")
+	}
+}
 
-	current := s.header
+// tryLink acquires the predecessor locks bottom-up, validates that the
+// snapshot captured by find is still accurate, and if so links a new node
+// spanning [0, topLevel]. It reports whether the link succeeded; on
+// failure the caller should retry from find. rank holds, for each level,
+// the bottom-level position of preds[level] as computed by findRanked, used to
+// split span counts between preds[level] and the new node.
+func (s *SkipMap[K, V]) tryLink(key K, value V, topLevel int, preds, succs []*Node[K, V], rank []int64) bool {
+	var prevPred *Node[K, V]
+	locked := make([]*Node[K, V], 0, topLevel+1)
+	valid := true
+
+	for layer := 0; valid && layer <= topLevel; layer++ {
+		pred := preds[layer]
+		succ := succs[layer]
+		if pred != prevPred {
+			pred.mu.Lock()
+			locked = append(locked, pred)
+			prevPred = pred
+		}
+		valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.forward[layer].Load() == succ
+	}
 
-	for i := s.level; i >= 0; i-- {
-		for current.forward[i] != nil && s.comparator(current.forward[i].key, key) < 0 {
-			current = current.forward[i]
+	defer func() {
+		for _, n := range locked {
+			n.mu.Unlock()
 		}
+	}()
+
+	if !valid {
+		return false
 	}
 
-	current = current.forward[0]
+	newNode := newNode(key, value, topLevel)
+	for layer := 0; layer <= topLevel; layer++ {
+		newNode.forward[layer].Store(succs[layer])
+		newNode.span[layer].Store(preds[layer].span[layer].Load() - (rank[0] - rank[layer]))
+		preds[layer].span[layer].Store(rank[0] - rank[layer] + 1)
+	}
+	newNode.back.Store(preds[0])
+	for layer := 0; layer <= topLevel; layer++ {
+		preds[layer].forward[layer].Store(newNode)
+	}
+	if succs[0] != nil {
+		succs[0].back.Store(newNode)
+	}
+	// Levels above topLevel aren't structurally touched by this insert, so
+	// their predecessor isn't locked here; bump their span lock-free (see
+	// the span field doc comment on Node for the consistency trade-off).
+	for layer := topLevel + 1; layer < s.maxLevel; layer++ {
+		preds[layer].span[layer].Add(1)
+	}
+	newNode.fullyLinked.Store(true)
+	s.length.Add(1)
+	return true
+}
+
+func (s *SkipMap[K, V]) Get(key K) (V, bool) {
+	pred := s.header
+	var curr *Node[K, V]
+
+	for i := s.maxLevel - 1; i >= 0; i-- {
+		curr = pred.forward[i].Load()
+		for curr != nil && s.comparator(curr.key, key) < 0 {
+			pred = curr
+			curr = pred.forward[i].Load()
+		}
+	}
 
-	if current != nil && s.comparator(current.key, key) == 0 {
-		return current.value, true
+	if curr != nil && s.comparator(curr.key, key) == 0 && curr.fullyLinked.Load() && !curr.marked.Load() {
+		return *curr.value.Load(), true
 	}
 	var zero V
 	return zero, false
 }
 
 func (s *SkipMap[K, V]) Remove(key K) bool {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	update := s.updateCache
-	current := s.header
+	_, removed := s.removeIf(key, func(V) bool { return true })
+	return removed
+}
 
-	for i := s.level; i >= 0; i-- {
-		for current.forward[i] != nil && s.comparator(current.forward[i].key, key) < 0 {
-			current = current.forward[i]
-		}
-		update[i] = current
-	}
+// LoadAndDelete removes key and returns the value it held, if present.
+func (s *SkipMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	return s.removeIf(key, func(V) bool { return true })
+}
 
-	current = current.forward[0]
+// CompareAndDelete removes key only if its current value equals old, as
+// reported by the equality function the map was constructed with (see
+// NewWithComparatorAndEquality and NewComparable). It reports whether the
+// delete took place.
+func (s *SkipMap[K, V]) CompareAndDelete(key K, old V) bool {
+	s.requireEqual()
+	_, removed := s.removeIf(key, func(v V) bool { return s.equal(v, old) })
+	return removed
+}
 
-	if current != nil && s.comparator(current.key, key) == 0 {
-		for i := 0; i <= s.level; i++ {
-			if update[i].forward[i] != current {
-				break
+// removeIf removes key if it is present and shouldRemove returns true for
+// its current value, evaluated while the candidate node's own lock is held
+// so the check-then-delete is a single critical section. It returns the
+// removed value and whether a removal happened.
+func (s *SkipMap[K, V]) removeIf(key K, shouldRemove func(V) bool) (removedValue V, removed bool) {
+	preds := make([]*Node[K, V], s.maxLevel)
+	succs := make([]*Node[K, V], s.maxLevel)
+	var victim *Node[K, V]
+	isMarked := false
+	topLevel := -1
+
+	for {
+		lFound := s.find(key, preds, succs)
+		if !isMarked {
+			if lFound == -1 {
+				var zero V
+				return zero, false
+			}
+			candidate := succs[lFound]
+			if !candidate.fullyLinked.Load() || candidate.topLevel != lFound || candidate.marked.Load() {
+				continue
 			}
-			update[i].forward[i] = current.forward[i]
+			candidate.mu.Lock()
+			if candidate.marked.Load() {
+				candidate.mu.Unlock()
+				continue
+			}
+			current := *candidate.value.Load()
+			if !shouldRemove(current) {
+				candidate.mu.Unlock()
+				var zero V
+				return zero, false
+			}
+			removedValue = current
+			victim = candidate
+			topLevel = victim.topLevel
+			victim.marked.Store(true)
+			isMarked = true
 		}
 
-		for s.level > 0 && s.header.forward[s.level] == nil {
-			s.level--
+		if s.tryUnlink(victim, topLevel, preds) {
+			return removedValue, true
 		}
-
-		s.length--
-		return true
 	}
-
-	return false
 }
 
-func (s *SkipMap[K, V]) Range(start, end K) []V {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	result := make([]V, 0)
-	current := s.header
-
-	for i := s.level; i >= 0; i-- {
-		for current.forward[i] != nil && s.comparator(current.forward[i].key, start) < 0 {
-			current = current.forward[i]
+// tryUnlink acquires the predecessor locks bottom-up for an already-marked
+// victim and, once the snapshot is confirmed valid, unlinks it level by
+// level from the top down, releasing victim's own lock (acquired by the
+// caller in removeIf) once it is safely detached. On an invalid snapshot it
+// reports false and leaves victim locked for the caller to retry with.
+func (s *SkipMap[K, V]) tryUnlink(victim *Node[K, V], topLevel int, preds []*Node[K, V]) bool {
+	var prevPred *Node[K, V]
+	locked := make([]*Node[K, V], 0, topLevel+1)
+	valid := true
+
+	for layer := 0; valid && layer <= topLevel; layer++ {
+		pred := preds[layer]
+		if pred != prevPred {
+			pred.mu.Lock()
+			locked = append(locked, pred)
+			prevPred = pred
 		}
+		valid = !pred.marked.Load() && pred.forward[layer].Load() == victim
 	}
 
-	current = current.forward[0]
+	defer func() {
+		for _, n := range locked {
+			n.mu.Unlock()
+		}
+	}()
+
+	if !valid {
+		// The snapshot is stale; the caller retries with victim still marked
+		// and still locked, so don't release victim.mu here.
+		return false
+	}
+	defer victim.mu.Unlock()
 
-	for current != nil && s.comparator(current.key, end) <= 0 {
-		result = append(result, current.value)
-		current = current.forward[0]
+	for layer := topLevel; layer >= 0; layer-- {
+		preds[layer].span[layer].Store(preds[layer].span[layer].Load() + victim.span[layer].Load() - 1)
+		preds[layer].forward[layer].Store(victim.forward[layer].Load())
 	}
+	if succ0 := victim.forward[0].Load(); succ0 != nil {
+		succ0.back.Store(preds[0])
+	}
+	// Symmetric with tryLink: levels above the victim's topLevel aren't
+	// structurally touched, so just account for the one fewer node below.
+	for layer := topLevel + 1; layer < s.maxLevel; layer++ {
+		preds[layer].span[layer].Add(-1)
+	}
+	s.length.Add(-1)
+	return true
+}
 
+func (s *SkipMap[K, V]) Range(start, end K) []V {
+	result := make([]V, 0)
+	s.RangeFunc(start, end, func(_ K, value V) bool {
+		result = append(result, value)
+		return true
+	})
 	return result
 }
 
 // RangeFunc iterates over the elements in the range [start, end] and calls f for each key-value pair.
 // If f returns false, iteration stops.
 func (s *SkipMap[K, V]) RangeFunc(start, end K, f func(key K, value V) bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	it := s.SeekGE(start)
+	defer it.Close()
 
-	current := s.header
-
-	// Find the start node
-	for i := s.level; i >= 0; i-- {
-		for current.forward[i] != nil && s.comparator(current.forward[i].key, start) < 0 {
-			current = current.forward[i]
+	for it.Valid() && s.comparator(it.Key(), end) <= 0 {
+		if !f(it.Key(), it.Value()) {
+			return
 		}
+		it.Next()
 	}
+}
 
-	current = current.forward[0]
-
-	// Iterate and call the function until the end of the range or the callback returns false
-	for current != nil && s.comparator(current.key, end) <= 0 {
-		if !f(current.key, current.value) {
-			break
+// forEach walks every live entry from the smallest key to the largest,
+// calling f for each. It stops early if f returns false.
+func (s *SkipMap[K, V]) forEach(f func(key K, value V) bool) {
+	curr := s.header.forward[0].Load()
+	for curr != nil {
+		if curr.fullyLinked.Load() && !curr.marked.Load() {
+			if !f(curr.key, *curr.value.Load()) {
+				return
+			}
 		}
-		current = current.forward[0]
+		curr = curr.forward[0].Load()
 	}
 }
 
 func (s *SkipMap[K, V]) Min() (K, V, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	var zeroK K
 	var zeroV V
 
-	if s.length == 0 {
+	curr := s.header.forward[0].Load()
+	for curr != nil && (!curr.fullyLinked.Load() || curr.marked.Load()) {
+		curr = curr.forward[0].Load()
+	}
+	if curr == nil {
 		return zeroK, zeroV, false
 	}
-
-	minNode := s.header.forward[0]
-	return minNode.key, minNode.value, true
+	return curr.key, *curr.value.Load(), true
 }
 
 func (s *SkipMap[K, V]) Max() (K, V, bool) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	var zeroK K
 	var zeroV V
 
-	if s.length == 0 {
-		return zeroK, zeroV, false
-	}
-
-	current := s.header
-	for i := s.level; i >= 0; i-- {
-		for current.forward[i] != nil {
-			current = current.forward[i]
+	pred := s.header
+	var curr *Node[K, V]
+	for i := s.maxLevel - 1; i >= 0; i-- {
+		for next := pred.forward[i].Load(); next != nil; next = pred.forward[i].Load() {
+			pred = next
 		}
 	}
-
-	return current.key, current.value, true
+	curr = pred
+	// The physically-rightmost node can be mid-insert (marked fullyLinked
+	// only after it's linked in) or mid-remove (marked before it's
+	// unlinked), so walk back to the nearest live node, same as SeekLE's
+	// fallback.
+	for curr != s.header && (curr.marked.Load() || !curr.fullyLinked.Load()) {
+		curr = curr.back.Load()
+	}
+	if curr == s.header {
+		return zeroK, zeroV, false
+	}
+	return curr.key, *curr.value.Load(), true
 }
 
 func (s *SkipMap[K, V]) Len() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.length
+	return int(s.length.Load())
 }
 
 func (s *SkipMap[K, V]) IsEmpty() bool {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.length == 0
+	return s.length.Load() == 0
 }
@@ -4,9 +4,127 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 )
 
+func TestSkipMap_LoadOrStore(t *testing.T) {
+	s := New[int, string]()
+
+	if v, loaded := s.LoadOrStore(1, "one"); loaded || v != "one" {
+		t.Errorf("LoadOrStore(1, \"one\") = (%q, %v), want (\"one\", false)", v, loaded)
+	}
+	if v, loaded := s.LoadOrStore(1, "uno"); !loaded || v != "one" {
+		t.Errorf("LoadOrStore(1, \"uno\") = (%q, %v), want (\"one\", true)", v, loaded)
+	}
+	if v, _ := s.Get(1); v != "one" {
+		t.Errorf("Get(1) = %q after LoadOrStore on existing key, want unchanged \"one\"", v)
+	}
+}
+
+func TestSkipMap_Swap(t *testing.T) {
+	s := New[int, string]()
+
+	if prev, loaded := s.Swap(1, "one"); loaded || prev != "" {
+		t.Errorf("Swap(1, \"one\") = (%q, %v), want (\"\", false)", prev, loaded)
+	}
+	if prev, loaded := s.Swap(1, "uno"); !loaded || prev != "one" {
+		t.Errorf("Swap(1, \"uno\") = (%q, %v), want (\"one\", true)", prev, loaded)
+	}
+	if v, _ := s.Get(1); v != "uno" {
+		t.Errorf("Get(1) = %q after Swap, want \"uno\"", v)
+	}
+}
+
+func TestSkipMap_LoadAndDelete(t *testing.T) {
+	s := New[int, string]()
+	s.Put(1, "one")
+
+	if v, loaded := s.LoadAndDelete(1); !loaded || v != "one" {
+		t.Errorf("LoadAndDelete(1) = (%q, %v), want (\"one\", true)", v, loaded)
+	}
+	if _, ok := s.Get(1); ok {
+		t.Error("Expected key 1 to be removed after LoadAndDelete")
+	}
+	if _, loaded := s.LoadAndDelete(1); loaded {
+		t.Error("Expected LoadAndDelete on missing key to report loaded=false")
+	}
+}
+
+func TestSkipMap_CompareAndSwap(t *testing.T) {
+	s := NewComparable[int, string]()
+	s.Put(1, "one")
+
+	if s.CompareAndSwap(1, "wrong", "uno") {
+		t.Error("Expected CompareAndSwap to fail when old value does not match")
+	}
+	if !s.CompareAndSwap(1, "one", "uno") {
+		t.Error("Expected CompareAndSwap to succeed when old value matches")
+	}
+	if v, _ := s.Get(1); v != "uno" {
+		t.Errorf("Get(1) = %q after CompareAndSwap, want \"uno\"", v)
+	}
+	if s.CompareAndSwap(2, "anything", "x") {
+		t.Error("Expected CompareAndSwap to fail for a missing key")
+	}
+}
+
+func TestSkipMap_CompareAndDelete(t *testing.T) {
+	s := NewComparable[int, string]()
+	s.Put(1, "one")
+
+	if s.CompareAndDelete(1, "wrong") {
+		t.Error("Expected CompareAndDelete to fail when old value does not match")
+	}
+	if !s.CompareAndDelete(1, "one") {
+		t.Error("Expected CompareAndDelete to succeed when old value matches")
+	}
+	if _, ok := s.Get(1); ok {
+		t.Error("Expected key 1 to be removed after CompareAndDelete")
+	}
+}
+
+func TestSkipMap_CompareAndSwapRequiresEquality(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected CompareAndSwap without an equality function to panic")
+		}
+	}()
+	s := New[int, string]()
+	s.CompareAndSwap(1, "a", "b")
+}
+
+func TestSkipMap_ConcurrentCompareAndSwap(t *testing.T) {
+	s := NewComparable[int, int]()
+	s.Put(1, 0)
+
+	const numGoroutines = 50
+	var wg sync.WaitGroup
+	var successes atomic.Int64
+
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, _ := s.Get(1)
+				if s.CompareAndSwap(1, v, v+1) {
+					successes.Add(1)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if successes.Load() != numGoroutines {
+		t.Errorf("Expected %d successful CompareAndSwap calls, got %d", numGoroutines, successes.Load())
+	}
+	if v, _ := s.Get(1); v != numGoroutines {
+		t.Errorf("Get(1) = %d after concurrent CompareAndSwap, want %d", v, numGoroutines)
+	}
+}
+
 func TestSkipMap_PutAndGet(t *testing.T) {
 	s := New[int, string]()
 
@@ -211,3 +329,90 @@ func TestSkipMap_ConcurrentAccess(t *testing.T) {
 		t.Errorf("Expected length to be %d after concurrent removes, but got %d", expectedLen, s.Len())
 	}
 }
+
+// TestSkipMap_ConcurrentMixedStress hammers Put/Get/Remove/Range from many
+// goroutines at once with no external synchronization, so run with -race to
+// catch any data race in the lock-free traversal or per-node locking.
+func TestSkipMap_ConcurrentMixedStress(t *testing.T) {
+	s := New[int, int]()
+	const (
+		numGoroutines = 64
+		numKeys       = 256
+		numOps        = 200
+	)
+
+	var wg sync.WaitGroup
+	for g := 0; g < numGoroutines; g++ {
+		wg.Add(1)
+		go func(gID int) {
+			defer wg.Done()
+			for i := 0; i < numOps; i++ {
+				key := (gID*31 + i) % numKeys
+				switch i % 4 {
+				case 0:
+					s.Put(key, key)
+				case 1:
+					s.Get(key)
+				case 2:
+					s.Remove(key)
+				case 3:
+					s.Range(0, numKeys)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// The map must still be internally consistent: every key reachable via
+	// Range must also be reachable via Get, and Len must match the count of
+	// entries actually linked into the list.
+	count := 0
+	s.RangeFunc(0, numKeys, func(key, value int) bool {
+		count++
+		if v, ok := s.Get(key); !ok || v != value {
+			t.Errorf("Get(%d) = (%d, %v), want (%d, true) after concurrent stress", key, v, ok, value)
+		}
+		return true
+	})
+	if count != s.Len() {
+		t.Errorf("Len() = %d, but RangeFunc observed %d entries", s.Len(), count)
+	}
+}
+
+// TestSkipMap_ConcurrentMaxDuringWrites guards against Max landing on the
+// physically-rightmost node while it's mid-insert (not yet fullyLinked) or
+// mid-remove (already marked): either way Max must keep walking back to the
+// nearest live node instead of reporting the map empty.
+func TestSkipMap_ConcurrentMaxDuringWrites(t *testing.T) {
+	s := New[int, int]()
+	const numKeys = 500
+	for i := 0; i < numKeys; i++ {
+		s.Put(i, i)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := numKeys; i < numKeys*2; i++ {
+			s.Put(i, i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := numKeys - 1; i >= numKeys-50; i-- {
+			s.Remove(i)
+		}
+	}()
+
+	for i := 0; i < 2000; i++ {
+		if _, _, ok := s.Max(); !ok {
+			t.Fatal("Max() reported the map empty while concurrent writers were still running")
+		}
+	}
+	wg.Wait()
+
+	if _, _, ok := s.Max(); !ok {
+		t.Fatal("Max() reported the map empty after writers settled")
+	}
+}